@@ -0,0 +1,18 @@
+// Package chi registers the handlers of an [adapters.Handlers] onto a
+// github.com/go-chi/chi/v5 router.
+package chi
+
+import (
+	"github.com/go-chi/chi/v5"
+
+	"github.com/gitu/zitadel-example/go-app/adapters"
+)
+
+// Register mounts the login, callback, logout and backchannel-logout
+// handlers under prefix (e.g. "/auth") using chi's native routing.
+func Register(r chi.Router, h adapters.Handlers, prefix string) {
+	r.Get(prefix+"/login", h.LoginHandler().ServeHTTP)
+	r.Get(prefix+"/callback", h.CallbackHandler().ServeHTTP)
+	r.Get(prefix+"/logout", h.LogoutHandler().ServeHTTP)
+	r.Post(prefix+"/backchannel-logout", h.BackchannelLogoutHandler().ServeHTTP)
+}
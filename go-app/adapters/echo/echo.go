@@ -0,0 +1,18 @@
+// Package echo registers the handlers of an [adapters.Handlers] onto a
+// github.com/labstack/echo/v4 router.
+package echo
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/gitu/zitadel-example/go-app/adapters"
+)
+
+// Register mounts the login, callback, logout and backchannel-logout
+// handlers under prefix (e.g. "/auth") using echo's native routing.
+func Register(e *echo.Echo, h adapters.Handlers, prefix string) {
+	e.GET(prefix+"/login", echo.WrapHandler(h.LoginHandler()))
+	e.GET(prefix+"/callback", echo.WrapHandler(h.CallbackHandler()))
+	e.GET(prefix+"/logout", echo.WrapHandler(h.LogoutHandler()))
+	e.POST(prefix+"/backchannel-logout", echo.WrapHandler(h.BackchannelLogoutHandler()))
+}
@@ -0,0 +1,18 @@
+// Package gin registers the handlers of an [adapters.Handlers] onto a
+// github.com/gin-gonic/gin router.
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/gitu/zitadel-example/go-app/adapters"
+)
+
+// Register mounts the login, callback, logout and backchannel-logout
+// handlers under prefix (e.g. "/auth") using gin's native routing.
+func Register(r gin.IRouter, h adapters.Handlers, prefix string) {
+	r.GET(prefix+"/login", gin.WrapH(h.LoginHandler()))
+	r.GET(prefix+"/callback", gin.WrapH(h.CallbackHandler()))
+	r.GET(prefix+"/logout", gin.WrapH(h.LogoutHandler()))
+	r.POST(prefix+"/backchannel-logout", gin.WrapH(h.BackchannelLogoutHandler()))
+}
@@ -0,0 +1,18 @@
+// Package gorillamux registers the handlers of an [adapters.Handlers] onto a
+// github.com/gorilla/mux router.
+package gorillamux
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/gitu/zitadel-example/go-app/adapters"
+)
+
+// Register mounts the login, callback, logout and backchannel-logout
+// handlers under prefix (e.g. "/auth") using gorilla/mux's native routing.
+func Register(r *mux.Router, h adapters.Handlers, prefix string) {
+	r.Handle(prefix+"/login", h.LoginHandler()).Methods("GET")
+	r.Handle(prefix+"/callback", h.CallbackHandler()).Methods("GET")
+	r.Handle(prefix+"/logout", h.LogoutHandler()).Methods("GET")
+	r.Handle(prefix+"/backchannel-logout", h.BackchannelLogoutHandler()).Methods("POST")
+}
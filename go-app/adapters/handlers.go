@@ -0,0 +1,21 @@
+// Package adapters defines the shared surface that router-specific adapter
+// packages (chi, gorillamux, gin, echo) register onto a host router, so that
+// authentication.Authentication does not have to understand any router's
+// native path-parameter syntax.
+package adapters
+
+import "net/http"
+
+// Handlers is the set of handlers an adapter package registers.
+// authentication.Authentication does not implement it itself (it exposes
+// Authenticate, Callback and Logout methods); the example's main package
+// wraps those, together with its own back-channel logout handler, into a type
+// that does. Each method returns a standalone http.Handler instead of authN
+// dispatching internally on a path prefix, which is what makes it usable
+// with routers other than net/http's ServeMux.
+type Handlers interface {
+	LoginHandler() http.Handler
+	CallbackHandler() http.Handler
+	LogoutHandler() http.Handler
+	BackchannelLogoutHandler() http.Handler
+}
@@ -0,0 +1,89 @@
+// Package authlog emits structured, named events for the authentication
+// lifecycle - login start, OIDC callback success/failure, logout and
+// session expiry - each carrying the subject/session/client/request
+// metadata needed to correlate an auth event with the access log line
+// reqlog emits for the same request (both end up carrying the same
+// "request_id", since the logger passed in here is the one reqlog scoped).
+//
+// Token refresh is deliberately not covered: the underlying zitadel-go SDK
+// (v3.29.2) performs no token refresh of its own - a session simply expires
+// and forces re-login - so there is no point in the request flow to
+// observe it from.
+package authlog
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// Event names for the auth.* log lines this package emits.
+const (
+	EventLoginStart      = "auth.login.start"
+	EventCallbackSuccess = "auth.callback.success"
+	EventCallbackFailure = "auth.callback.failure"
+	EventLogout          = "auth.logout"
+	EventSessionExpired  = "auth.session.expired"
+)
+
+type requestContextKey struct{}
+
+// WithRequest stashes req in ctx so a later [CallbackSuccess] call - made
+// from inside the SDK's OnAuthenticated hook, which only receives a
+// context.Context - can still log the request's ip/user_agent.
+func WithRequest(ctx context.Context, req *http.Request) context.Context {
+	return context.WithValue(ctx, requestContextKey{}, req)
+}
+
+// RequestFromContext returns the *http.Request stashed by [WithRequest], or
+// nil if ctx wasn't produced by it.
+func RequestFromContext(ctx context.Context) *http.Request {
+	req, _ := ctx.Value(requestContextKey{}).(*http.Request)
+	return req
+}
+
+// LoginStart logs that a login redirect to the Login UI is about to happen.
+func LoginStart(ctx context.Context, logger *slog.Logger, req *http.Request, clientID string) {
+	logger.InfoContext(ctx, EventLoginStart,
+		"client_id", clientID, "ip", clientIP(req), "user_agent", req.UserAgent())
+}
+
+// CallbackSuccess logs a completed OIDC callback that established a session.
+func CallbackSuccess(ctx context.Context, logger *slog.Logger, req *http.Request, clientID, sub, sid string) {
+	logger.InfoContext(ctx, EventCallbackSuccess,
+		"sub", sub, "sid", sid, "client_id", clientID, "ip", clientIP(req), "user_agent", req.UserAgent())
+}
+
+// CallbackFailure logs an OIDC callback that did not establish a session.
+// errorCode is the authorization endpoint's own "error" query parameter
+// (e.g. "access_denied") when present; otherwise the SDK rejected the
+// callback internally (e.g. a failed code exchange or state mismatch),
+// which it does not surface as a machine-readable reason, so errorCode is
+// "unknown".
+func CallbackFailure(ctx context.Context, logger *slog.Logger, req *http.Request, clientID, errorCode string) {
+	logger.WarnContext(ctx, EventCallbackFailure,
+		"error_code", errorCode, "client_id", clientID, "ip", clientIP(req), "user_agent", req.UserAgent())
+}
+
+// Logout logs a session being ended, whether front-channel (the user hit
+// /auth/logout) or back-channel (ZITADEL called /auth/backchannel-logout).
+func Logout(ctx context.Context, logger *slog.Logger, req *http.Request, clientID, sub, sid string) {
+	logger.InfoContext(ctx, EventLogout,
+		"sub", sub, "sid", sid, "client_id", clientID, "ip", clientIP(req), "user_agent", req.UserAgent())
+}
+
+// SessionExpired logs that a stored session was found expired on lookup.
+// It carries less context than the events above: [authentication.Sessions.Get],
+// the only place this is observable, is called by the SDK with just a
+// session id and no context.Context or *http.Request, so ip/user_agent/
+// correlation id aren't available here.
+func SessionExpired(logger *slog.Logger, id, sub string) {
+	logger.Warn(EventSessionExpired, "id", id, "sub", sub)
+}
+
+func clientIP(req *http.Request) string {
+	if ip := req.Header.Get("X-Forwarded-For"); ip != "" {
+		return ip
+	}
+	return req.RemoteAddr
+}
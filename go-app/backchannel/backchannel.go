@@ -0,0 +1,67 @@
+// Package backchannel validates OIDC back-channel logout tokens as defined
+// by https://openid.net/specs/openid-connect-backchannel-1_0.html, so that a
+// server-side session can be invalidated without relying on the user's
+// browser to follow a front-channel redirect.
+package backchannel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// backchannelLogoutEvent is the event type that must be present in a logout
+// token's "events" claim, per the spec.
+const backchannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// ErrInvalidLogoutToken is returned when a logout token fails any of the
+// structural or claim checks required by the spec.
+var ErrInvalidLogoutToken = errors.New("backchannel: invalid logout token")
+
+// LogoutToken holds the claims of a validated back-channel logout token that
+// are needed to locate the server-side session to invalidate.
+type LogoutToken struct {
+	Subject   string
+	SessionID string
+}
+
+// KeySetProvider resolves the key used to verify a logout token's signature,
+// typically backed by the issuer's JWKS.
+type KeySetProvider interface {
+	VerificationKey(ctx context.Context, token *jwt.Token) (any, error)
+}
+
+// Validate parses and validates a logout token as required by the
+// back-channel logout spec: signature, issuer, audience, the presence of the
+// backchannel-logout event, and that at least one of "sid"/"sub" is set. It
+// deliberately does not accept a "nonce" claim, which the spec forbids.
+func Validate(ctx context.Context, rawToken, issuer, audience string, keys KeySetProvider) (*LogoutToken, error) {
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(rawToken, claims, func(t *jwt.Token) (any, error) {
+		return keys.VerificationKey(ctx, t)
+	}, jwt.WithIssuer(issuer), jwt.WithAudience(audience), jwt.WithValidMethods([]string{"RS256"}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+	}
+	if !token.Valid {
+		return nil, ErrInvalidLogoutToken
+	}
+	if _, ok := claims["nonce"]; ok {
+		return nil, fmt.Errorf("%w: must not contain a nonce claim", ErrInvalidLogoutToken)
+	}
+
+	events, _ := claims["events"].(map[string]any)
+	if _, ok := events[backchannelLogoutEvent]; !ok {
+		return nil, fmt.Errorf("%w: missing backchannel-logout event", ErrInvalidLogoutToken)
+	}
+
+	sub, _ := claims["sub"].(string)
+	sid, _ := claims["sid"].(string)
+	if sub == "" && sid == "" {
+		return nil, fmt.Errorf("%w: must contain sub or sid", ErrInvalidLogoutToken)
+	}
+
+	return &LogoutToken{Subject: sub, SessionID: sid}, nil
+}
@@ -0,0 +1,147 @@
+package backchannel
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "test-client"
+)
+
+type staticKeySet struct {
+	key *rsa.PublicKey
+}
+
+func (s staticKeySet) VerificationKey(_ context.Context, _ *jwt.Token) (any, error) {
+	return s.key, nil
+}
+
+func signLogoutToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("signing test token: %v", err)
+	}
+	return token
+}
+
+func baseClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss": testIssuer,
+		"aud": testAudience,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Minute).Unix(),
+		"jti": "test-jti",
+		"events": map[string]any{
+			backchannelLogoutEvent: map[string]any{},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating test key: %v", err)
+	}
+	keys := staticKeySet{key: &key.PublicKey}
+
+	tests := []struct {
+		name    string
+		mutate  func(jwt.MapClaims)
+		wantErr bool
+		wantSub string
+		wantSID string
+	}{
+		{
+			name: "valid with sub and sid",
+			mutate: func(c jwt.MapClaims) {
+				c["sub"] = "user-1"
+				c["sid"] = "session-1"
+			},
+			wantSub: "user-1",
+			wantSID: "session-1",
+		},
+		{
+			name: "valid with sid only",
+			mutate: func(c jwt.MapClaims) {
+				c["sid"] = "session-1"
+			},
+			wantSID: "session-1",
+		},
+		{
+			name: "missing backchannel-logout event",
+			mutate: func(c jwt.MapClaims) {
+				c["sub"] = "user-1"
+				c["events"] = map[string]any{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "neither sub nor sid",
+			mutate: func(c jwt.MapClaims) {
+				// leave both unset
+			},
+			wantErr: true,
+		},
+		{
+			name: "contains a nonce claim",
+			mutate: func(c jwt.MapClaims) {
+				c["sub"] = "user-1"
+				c["nonce"] = "must-not-be-here"
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong issuer",
+			mutate: func(c jwt.MapClaims) {
+				c["sub"] = "user-1"
+				c["iss"] = "https://not-the-issuer.example.com"
+			},
+			wantErr: true,
+		},
+		{
+			name: "wrong audience",
+			mutate: func(c jwt.MapClaims) {
+				c["sub"] = "user-1"
+				c["aud"] = "not-the-client"
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := baseClaims()
+			tt.mutate(claims)
+			raw := signLogoutToken(t, key, claims)
+
+			got, err := Validate(context.Background(), raw, testIssuer, testAudience, keys)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				if !errors.Is(err, ErrInvalidLogoutToken) {
+					t.Errorf("expected error to wrap ErrInvalidLogoutToken, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Subject != tt.wantSub {
+				t.Errorf("Subject = %q, want %q", got.Subject, tt.wantSub)
+			}
+			if got.SessionID != tt.wantSID {
+				t.Errorf("SessionID = %q, want %q", got.SessionID, tt.wantSID)
+			}
+		})
+	}
+}
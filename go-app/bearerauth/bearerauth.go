@@ -0,0 +1,172 @@
+// Package bearerauth authenticates API (non-browser) requests carrying an
+// "Authorization: Bearer" access token, via RFC 7662 token introspection
+// against the issuing ZITADEL instance, and responds with RFC 6750
+// WWW-Authenticate challenges on failure.
+package bearerauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/client/rs"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+)
+
+// defaultMaxCacheTTL is the maxCacheTTL New falls back to when given <= 0.
+const defaultMaxCacheTTL = 5 * time.Minute
+
+type cacheEntry struct {
+	resp      oidc.IntrospectionResponse
+	expiresAt time.Time
+}
+
+// Middleware requires a valid, active access token carrying every scope in
+// requiredScopes, verified via introspection against resourceServer.
+type Middleware struct {
+	resourceServer rs.ResourceServer
+	requiredScopes []string
+	maxCacheTTL    time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+// New returns a Middleware that introspects tokens via resourceServer and
+// requires each of requiredScopes to be present in the introspection
+// response. A successful result is cached until the token's own "exp"
+// claim, capped at maxCacheTTL (<= 0 uses defaultMaxCacheTTL) so that a
+// token revoked upstream is still noticed within a bounded time even if it
+// carries a very long expiry.
+func New(resourceServer rs.ResourceServer, maxCacheTTL time.Duration, requiredScopes ...string) *Middleware {
+	if maxCacheTTL <= 0 {
+		maxCacheTTL = defaultMaxCacheTTL
+	}
+	return &Middleware{
+		resourceServer: resourceServer,
+		requiredScopes: requiredScopes,
+		maxCacheTTL:    maxCacheTTL,
+		cache:          make(map[string]cacheEntry),
+	}
+}
+
+type contextKey struct{}
+
+// Introspection returns the introspection response [Middleware.RequireBearerToken]
+// stored in ctx, or the zero value if ctx wasn't produced by it.
+func Introspection(ctx context.Context) oidc.IntrospectionResponse {
+	info, _ := ctx.Value(contextKey{}).(oidc.IntrospectionResponse)
+	return info
+}
+
+// RequireBearerToken introspects the request's bearer token and rejects the
+// request with an RFC 6750 challenge unless it is active and carries every
+// required scope. On success, the introspection response is made available
+// to next via [Introspection].
+func (m *Middleware) RequireBearerToken() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			token, err := bearerToken(req)
+			if err != nil {
+				challenge(w, "invalid_request", err.Error())
+				return
+			}
+			info, err := m.introspect(req.Context(), token)
+			if err != nil {
+				challenge(w, "invalid_token", "token introspection failed")
+				return
+			}
+			if !info.Active {
+				challenge(w, "invalid_token", "token is not active")
+				return
+			}
+			for _, scope := range m.requiredScopes {
+				if !hasScope(info.Scope, scope) {
+					challenge(w, "insufficient_scope", fmt.Sprintf("token is missing required scope %q", scope))
+					return
+				}
+			}
+			next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), contextKey{}, info)))
+		})
+	}
+}
+
+func (m *Middleware) introspect(ctx context.Context, token string) (oidc.IntrospectionResponse, error) {
+	now := time.Now()
+	m.mu.Lock()
+	if e, ok := m.cache[token]; ok {
+		if now.Before(e.expiresAt) {
+			m.mu.Unlock()
+			return e.resp, nil
+		}
+		delete(m.cache, token)
+	}
+	m.mu.Unlock()
+
+	resp, err := rs.Introspect[oidc.IntrospectionResponse](ctx, m.resourceServer, token)
+	if err != nil {
+		return oidc.IntrospectionResponse{}, err
+	}
+	m.mu.Lock()
+	m.cache[token] = cacheEntry{resp: resp, expiresAt: cacheExpiry(resp, now, m.maxCacheTTL)}
+	m.evictExpiredLocked(now)
+	m.mu.Unlock()
+	return resp, nil
+}
+
+// cacheExpiry is the earlier of the token's own "exp" claim and
+// now+maxCacheTTL, so a long-lived token is still re-checked periodically.
+func cacheExpiry(resp oidc.IntrospectionResponse, now time.Time, maxCacheTTL time.Duration) time.Time {
+	capped := now.Add(maxCacheTTL)
+	if resp.Expiration == 0 {
+		return capped
+	}
+	if exp := resp.Expiration.AsTime(); exp.Before(capped) {
+		return exp
+	}
+	return capped
+}
+
+// evictExpiredLocked drops cache entries that have already expired, so the
+// cache can't grow without bound as distinct tokens are seen. m.mu must be
+// held by the caller.
+func (m *Middleware) evictExpiredLocked(now time.Time) {
+	for token, e := range m.cache {
+		if !now.Before(e.expiresAt) {
+			delete(m.cache, token)
+		}
+	}
+}
+
+func bearerToken(req *http.Request) (string, error) {
+	const prefix = "Bearer "
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return "", errors.New("missing bearer token")
+	}
+	return strings.TrimPrefix(auth, prefix), nil
+}
+
+func hasScope(scopes oidc.SpaceDelimitedArray, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// challenge writes the RFC 6750 WWW-Authenticate header and matching status
+// code for a failed bearer token check.
+func challenge(w http.ResponseWriter, errCode, description string) {
+	status := http.StatusUnauthorized
+	if errCode == "insufficient_scope" {
+		status = http.StatusForbidden
+	}
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer error=%q, error_description=%q`, errCode, description))
+	http.Error(w, description, status)
+}
@@ -0,0 +1,66 @@
+package bearerauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{name: "valid bearer token", header: "Bearer abc123", want: "abc123"},
+		{name: "missing header", header: "", wantErr: true},
+		{name: "wrong scheme", header: "Basic abc123", wantErr: true},
+		{name: "bearer with no token is treated as a valid empty token", header: "Bearer ", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			got, err := bearerToken(req)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("bearerToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes oidc.SpaceDelimitedArray
+		scope  string
+		want   bool
+	}{
+		{name: "present among several", scopes: oidc.SpaceDelimitedArray{"openid", "profile", "email"}, scope: "profile", want: true},
+		{name: "absent", scopes: oidc.SpaceDelimitedArray{"openid", "email"}, scope: "profile", want: false},
+		{name: "empty scopes", scopes: nil, scope: "profile", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasScope(tt.scopes, tt.scope); got != tt.want {
+				t.Errorf("hasScope(%v, %q) = %v, want %v", tt.scopes, tt.scope, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// Package jwks resolves JWT verification keys from an OIDC issuer's
+// published JSON Web Key Set, discovered via the issuer's
+// .well-known/openid-configuration document.
+package jwks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	oidcclient "github.com/zitadel/oidc/v3/pkg/client"
+)
+
+// Provider caches an issuer's JWKS and resolves the key referenced by a
+// token's "kid" header, satisfying [backchannel.KeySetProvider] and usable
+// anywhere else a jwt.Keyfunc-shaped lookup is needed (e.g. bearer token
+// verification).
+type Provider struct {
+	issuer     string
+	httpClient *http.Client
+
+	mu     sync.RWMutex
+	keySet jose.JSONWebKeySet
+}
+
+// New returns a Provider for issuer. The JWKS itself is fetched lazily, on
+// first VerificationKey call.
+func New(issuer string) *Provider {
+	return &Provider{issuer: issuer, httpClient: http.DefaultClient}
+}
+
+// VerificationKey resolves the public key referenced by token's "kid"
+// header. If the kid isn't in the cached key set - either because the set
+// was never fetched, or because the issuer rotated its keys - the set is
+// (re-)fetched once before giving up.
+func (p *Provider) VerificationKey(ctx context.Context, token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := p.lookup(kid); ok {
+		return key, nil
+	}
+	if err := p.refresh(ctx); err != nil {
+		return nil, fmt.Errorf("jwks: fetching keys for %q: %w", p.issuer, err)
+	}
+	key, ok := p.lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("jwks: no key found for kid %q", kid)
+	}
+	return key, nil
+}
+
+func (p *Provider) lookup(kid string) (any, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	for _, k := range p.keySet.Key(kid) {
+		return k.Key, true
+	}
+	return nil, false
+}
+
+func (p *Provider) refresh(ctx context.Context) error {
+	discovery, err := oidcclient.Discover(ctx, p.issuer, p.httpClient)
+	if err != nil {
+		return fmt.Errorf("discovering issuer: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discovery.JwksURI, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s fetching %s", resp.Status, discovery.JwksURI)
+	}
+	var keySet jose.JSONWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keySet); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.keySet = keySet
+	p.mu.Unlock()
+	return nil
+}
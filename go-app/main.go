@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"crypto/rand"
+	"database/sql"
 	"embed"
 	_ "embed"
 	"encoding/hex"
@@ -15,18 +16,46 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/zitadel/oidc/v3/pkg/client/rs"
 	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
 	openid "github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc"
+	zitadelclient "github.com/zitadel/zitadel-go/v3/pkg/client"
 	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
 	"gopkg.in/yaml.v3"
+
+	_ "github.com/lib/pq"
+
+	"github.com/gitu/zitadel-example/go-app/adapters"
+	chiadapter "github.com/gitu/zitadel-example/go-app/adapters/chi"
+	echoadapter "github.com/gitu/zitadel-example/go-app/adapters/echo"
+	ginadapter "github.com/gitu/zitadel-example/go-app/adapters/gin"
+	gorillamuxadapter "github.com/gitu/zitadel-example/go-app/adapters/gorillamux"
+	"github.com/gitu/zitadel-example/go-app/authlog"
+	"github.com/gitu/zitadel-example/go-app/backchannel"
+	"github.com/gitu/zitadel-example/go-app/bearerauth"
+	"github.com/gitu/zitadel-example/go-app/jwks"
+	"github.com/gitu/zitadel-example/go-app/reqlog"
+	"github.com/gitu/zitadel-example/go-app/sessionstore"
 )
 
 var (
 	// flags to be provided for running the example server
-	key        = flag.String("key", "", "encryption key")
-	port       = flag.String("port", "8091", "port to run the server on (default is 8089)")
-	configFile = flag.String("config", "../secrets/direct-client.yaml", "path to the configuration file")
+	key            = flag.String("key", "", "encryption key")
+	port           = flag.String("port", "8091", "port to run the server on (default is 8089)")
+	configFile     = flag.String("config", "../secrets/direct-client.yaml", "path to the configuration file")
+	sessionKind    = flag.String("session-store", "memory", "backend used to persist sessions server-side: memory, redis or postgres")
+	sessionDSN     = flag.String("session-store-dsn", "", "connection string for the redis/postgres session store (ignored for memory)")
+	logFormat      = flag.String("log-format", "text", "log output format: text or json")
+	mode           = flag.String("mode", "user", "authentication mode to demonstrate: user (OIDC/PKCE) or m2m (service account)")
+	routerKind     = flag.String("router", "stdlib", "router to register the example's handlers on: stdlib, chi, gorillamux, gin or echo")
+	bearerCacheTTL = flag.Duration("bearer-introspection-cache-ttl", 5*time.Minute, "maximum time to cache a bearer token introspection result for (/api/me); also capped by the token's own expiry")
 	//go:embed "templates/*.html"
 	templates embed.FS
 )
@@ -45,6 +74,9 @@ to be able to redirect the user to the Login UI and back for authentication as w
 func main() {
 	flag.Parse()
 
+	logger := newLogger(*logFormat)
+	slog.SetDefault(logger)
+
 	config, err := LoadConfig(*configFile)
 	if err != nil {
 		slog.Error("error loading config", "error", err)
@@ -69,16 +101,46 @@ func main() {
 
 	ctx := context.Background()
 
+	// -mode=m2m authenticates the process itself against ZITADEL's API
+	// instead of running the interactive OIDC/PKCE flow below.
+	if *mode == "m2m" {
+		if err := runM2M(ctx, config); err != nil {
+			slog.Error("m2m authentication failed", "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	t, err := template.New("").ParseFS(templates, "templates/*.html")
 	if err != nil {
 		slog.Error("unable to parse template", "error", err)
 		os.Exit(1)
 	}
 
+	store, err := newSessionStore(*sessionKind, *sessionDSN)
+	if err != nil {
+		slog.Error("unable to initialize session store", "error", err)
+		os.Exit(1)
+	}
+
 	// Initiate the authentication by providing a zitadel configuration and handler.
 	// This example will use OIDC/OAuth2 PKCE Flow, therefore you will also need to initialize that with the generated client_id:
+	// WithSessionStore persists sessions server-side instead of only in the
+	// encrypted cookie: store satisfies [authentication.Sessions], so authN
+	// itself calls store.Set on every successful login and store.Get on
+	// every authenticated request, which is what actually populates it. That
+	// lets an operator enumerate and revoke sessions via the /auth/sessions
+	// endpoint registered below.
 	authN, err := authentication.New(ctx, zitadel.New(config.ZitadelDomain, zitadel.WithInsecure(config.ZitadelPort)), config.Key,
 		openid.DefaultAuthentication(config.ClientID, config.RedirectURL, config.Key),
+		authentication.WithSessionStore(store),
+		authentication.WithPostLogoutRedirectURI[*openid.DefaultContext](config.PostLogoutRedirectURL),
+		authentication.WithLogger[*openid.DefaultContext](logger),
+		authentication.WithOnAuthenticated[*openid.DefaultContext](func(ctx context.Context, authCtx *openid.DefaultContext) error {
+			authlog.CallbackSuccess(ctx, reqlog.FromContext(ctx), authlog.RequestFromContext(ctx), config.ClientID,
+				authCtx.UserInfo.GetSubject(), sessionIDOf(authCtx))
+			return nil
+		}),
 	)
 	if err != nil {
 		slog.Error("zitadel sdk could not initialize", "error", err)
@@ -88,34 +150,112 @@ func main() {
 	// Initialize the middleware by providing the sdk
 	mw := authentication.Middleware(authN)
 
-	router := http.NewServeMux()
+	// keys resolves the RSA public key a back-channel logout token was
+	// signed with from ZITADEL's own JWKS, discovered from config.Issuer.
+	// authN itself exposes no such key material, so this is independent of
+	// the authentication flow above.
+	keys := jwks.New(config.Issuer)
 
-	// Register the authentication handler on your desired path.
-	// It will register the following handlers on it:
-	// - /login (starts the authentication process to the Login UI)
-	// - /callback (handles the redirect back from the Login UI)
-	// - /logout (handles the logout process)
-	router.Handle("/auth/", authN)
+	// /auth/backchannel-logout is called directly by ZITADEL (not the user's
+	// browser) when a session ends OP-initiated, e.g. an admin forcing a
+	// logout elsewhere. It carries a logout_token instead of a redirect, so
+	// it needs its own handler rather than the front-channel /auth/logout.
+	backchannelLogoutHandler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		logoutToken, err := backchannel.Validate(req.Context(), req.PostForm.Get("logout_token"), config.Issuer, config.ClientID, keys)
+		if err != nil {
+			reqlog.FromContext(req.Context()).Error("error validating backchannel logout token", "error", err)
+			http.Error(w, "invalid logout token", http.StatusBadRequest)
+			return
+		}
+		if logoutToken.SessionID != "" {
+			err = store.Delete(req.Context(), logoutToken.SessionID)
+		} else {
+			var ids []string
+			ids, err = store.List(req.Context(), logoutToken.Subject)
+			for _, id := range ids {
+				if delErr := store.Delete(req.Context(), id); delErr != nil {
+					err = delErr
+				}
+			}
+		}
+		if err != nil {
+			reqlog.FromContext(req.Context()).Error("error revoking session from backchannel logout", "error", err)
+			http.Error(w, "unable to revoke session", http.StatusInternalServerError)
+			return
+		}
+		authlog.Logout(req.Context(), reqlog.FromContext(req.Context()), req, config.ClientID, logoutToken.Subject, logoutToken.SessionID)
+		w.WriteHeader(http.StatusOK)
+	})
+	// /auth/sessions lets the authenticated caller list their own active
+	// server-side sessions (GET) and forcibly revoke one of their own
+	// (DELETE ?id=...), something the cookie-only flow cannot do on its
+	// own. It is scoped to the caller's own subject, never an arbitrary
+	// ?user_id=, and a DELETE first checks the target session's owner -
+	// otherwise any logged-in user could enumerate or revoke another
+	// user's sessions.
+	sessionsHandler := mw.RequireAuthentication()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		sub := mw.Context(req.Context()).UserInfo.GetSubject()
+		switch req.Method {
+		case http.MethodGet:
+			ids, err := store.List(req.Context(), sub)
+			if err != nil {
+				reqlog.FromContext(req.Context()).Error("error listing sessions", "error", err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if err := json.NewEncoder(w).Encode(ids); err != nil {
+				reqlog.FromContext(req.Context()).Error("error writing sessions response", "error", err)
+			}
+		case http.MethodDelete:
+			id := req.URL.Query().Get("id")
+			session, err := store.Get(id)
+			if err != nil {
+				if errors.Is(err, sessionstore.ErrNotFound) {
+					http.Error(w, "not found", http.StatusNotFound)
+					return
+				}
+				reqlog.FromContext(req.Context()).Error("error looking up session", "error", err, "id", id)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if session.UserInfo.GetSubject() != sub {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			if err := store.Delete(req.Context(), id); err != nil {
+				reqlog.FromContext(req.Context()).Error("error revoking session", "error", err, "id", id)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	}))
 	// This endpoint is only accessible with a valid authentication. If there is none, it will directly redirect the user
 	// to the Login UI for authentication. If successful (or already authenticated), the user will be presented the profile page.
-	router.Handle("/profile", mw.RequireAuthentication()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	profileHandler := mw.RequireAuthentication()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		// Using the [middleware.Context] function we can gather information about the authenticated user.
 		// This example will just print a JSON representation of the UserInfo of the typed [*oidc.UserInfoContext].
 		authCtx := mw.Context(req.Context())
 		data, err := json.MarshalIndent(authCtx.UserInfo, "", " ")
 		if err != nil {
-			slog.Error("error marshalling profile response", "error", err)
+			reqlog.FromContext(req.Context()).Error("error marshalling profile response", "error", err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 		err = t.ExecuteTemplate(w, "profile.html", string(data))
 		if err != nil {
-			slog.Error("error writing profile response", "error", err)
+			reqlog.FromContext(req.Context()).Error("error writing profile response", "error", err)
 		}
-	})))
+	}))
 	// This endpoint is accessible by anyone, but it will check if there already is a valid session (authentication).
 	// If there is an active session, the information will be put into the context for later retrieval.
-	router.Handle("/", mw.CheckAuthentication()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	homeHandler := mw.CheckAuthentication()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		// In this case we check for an active session and directly redirect the user to the profile page.
 		// You could certainly also use [middleware.Context] to get more information and use it in the home page.
 		if authentication.IsAuthenticated(req.Context()) {
@@ -124,14 +264,143 @@ func main() {
 		}
 		err = t.ExecuteTemplate(w, "home.html", nil)
 		if err != nil {
-			slog.Error("error writing home page response", "error", err)
+			reqlog.FromContext(req.Context()).Error("error writing home page response", "error", err)
+		}
+	}))
+	// /api/me is a non-browser counterpart to /profile: instead of
+	// redirecting to the Login UI, it expects an "Authorization: Bearer"
+	// token, verifies it via RFC 7662 introspection and responds with
+	// RFC 6750 challenges on failure, so that API clients can be
+	// authenticated without ever holding the session cookie. Introspection
+	// needs its own client credential (service_account_key_path or
+	// client_secret); the standard PKCE demo config has neither, since a
+	// public client can't hold one, so /api/me is simply not registered in
+	// that case instead of refusing to start the whole server over a
+	// feature most configs won't use.
+	var apiMeHandler http.Handler
+	resourceServer, err := newResourceServer(ctx, config)
+	if err != nil {
+		slog.Warn("skipping /api/me: no introspection credential configured", "error", err)
+	} else {
+		bearer := bearerauth.New(resourceServer, *bearerCacheTTL, "openid", "profile")
+		apiMeHandler = bearer.RequireBearerToken()(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(bearerauth.Introspection(req.Context())); err != nil {
+				reqlog.FromContext(req.Context()).Error("error writing /api/me response", "error", err)
+			}
+		}))
+	}
+
+	// The router adapter packages let authN's individual handlers be
+	// registered on whichever router the host application already uses,
+	// instead of requiring net/http's ServeMux prefix-matching semantics.
+	// authN itself only exposes Authenticate/Callback/Logout (dispatched
+	// internally when used as an http.Handler via ServeHTTP), so each is
+	// wrapped into a standalone http.Handler here.
+	handlers := handlerSet{
+		login: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			authlog.LoginStart(req.Context(), reqlog.FromContext(req.Context()), req, config.ClientID)
+			authN.Authenticate(w, req, "")
+		}),
+		callback: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			// authN.Callback never returns an error, only a response already
+			// written to w: on failure it's an http.Error with no machine
+			// readable reason. rec lets us notice that without changing what
+			// the client receives, so a failed callback can still be logged.
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			authN.Callback(rec, req.WithContext(authlog.WithRequest(req.Context(), req)))
+			if rec.status >= http.StatusBadRequest {
+				errorCode := req.URL.Query().Get("error")
+				if errorCode == "" {
+					errorCode = "unknown"
+				}
+				authlog.CallbackFailure(req.Context(), reqlog.FromContext(req.Context()), req, config.ClientID, errorCode)
+			}
+		}),
+		logout: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if authCtx, err := authN.IsAuthenticated(req); err == nil {
+				authlog.Logout(req.Context(), reqlog.FromContext(req.Context()), req, config.ClientID,
+					authCtx.UserInfo.GetSubject(), sessionIDOf(authCtx))
+			}
+			authN.Logout(w, req)
+		}),
+		backchannelLogout: backchannelLogoutHandler,
+	}
+
+	var router http.Handler
+	switch *routerKind {
+	case "chi":
+		r := chi.NewRouter()
+		chiadapter.Register(r, handlers, "/auth")
+		r.Method(http.MethodGet, "/auth/sessions", sessionsHandler)
+		r.Method(http.MethodDelete, "/auth/sessions", sessionsHandler)
+		r.Method(http.MethodGet, "/profile", profileHandler)
+		if apiMeHandler != nil {
+			r.Method(http.MethodGet, "/api/me", apiMeHandler)
+		}
+		r.Method(http.MethodGet, "/", homeHandler)
+		router = r
+	case "gorillamux":
+		r := mux.NewRouter()
+		gorillamuxadapter.Register(r, handlers, "/auth")
+		r.Handle("/auth/sessions", sessionsHandler)
+		r.Handle("/profile", profileHandler)
+		if apiMeHandler != nil {
+			r.Handle("/api/me", apiMeHandler)
+		}
+		r.Handle("/", homeHandler)
+		router = r
+	case "gin":
+		r := gin.New()
+		ginadapter.Register(r, handlers, "/auth")
+		r.Any("/auth/sessions", gin.WrapH(sessionsHandler))
+		r.GET("/profile", gin.WrapH(profileHandler))
+		if apiMeHandler != nil {
+			r.GET("/api/me", gin.WrapH(apiMeHandler))
+		}
+		r.GET("/", gin.WrapH(homeHandler))
+		router = r
+	case "echo":
+		e := echo.New()
+		echoadapter.Register(e, handlers, "/auth")
+		e.Any("/auth/sessions", echo.WrapHandler(sessionsHandler))
+		e.GET("/profile", echo.WrapHandler(profileHandler))
+		if apiMeHandler != nil {
+			e.GET("/api/me", echo.WrapHandler(apiMeHandler))
 		}
-	})))
+		e.GET("/", echo.WrapHandler(homeHandler))
+		router = e
+	case "stdlib", "":
+		serveMux := http.NewServeMux()
+		// Register the authentication handler on your desired path.
+		// It will register the following handlers on it:
+		// - /login (starts the authentication process to the Login UI)
+		// - /callback (handles the redirect back from the Login UI)
+		// - /logout (handles the logout process)
+		serveMux.Handle("/auth/", authN)
+		serveMux.Handle("/auth/backchannel-logout", backchannelLogoutHandler)
+		serveMux.Handle("/auth/sessions", sessionsHandler)
+		serveMux.Handle("/profile", profileHandler)
+		if apiMeHandler != nil {
+			serveMux.Handle("/api/me", apiMeHandler)
+		}
+		serveMux.Handle("/", homeHandler)
+		router = serveMux
+	default:
+		slog.Error("unknown router", "router", *routerKind)
+		os.Exit(1)
+	}
+
+	// reqlog.Middleware wraps every request with a correlation id and an INFO
+	// access log, and makes a logger carrying that id available to the
+	// handlers above via reqlog.FromContext, so their own log lines can be
+	// traced back to the request that produced them.
+	handler := reqlog.Middleware(logger)(router)
 
 	// start the server on the specified port (default http://localhost:8089)
 	lis := fmt.Sprintf(":%s", *port)
 	slog.Info("server listening, press ctrl+c to stop", "addr", "http://localhost"+lis)
-	err = http.ListenAndServe(lis, router)
+	err = http.ListenAndServe(lis, handler)
 	if !errors.Is(err, http.ErrServerClosed) {
 		slog.Error("server terminated", "error", err)
 		os.Exit(1)
@@ -145,6 +414,17 @@ type Config struct {
 	Key           string `yaml:"key"`
 	ClientID      string `yaml:"client_id"`
 	RedirectURL   string `yaml:"redirect_url"`
+	// PostLogoutRedirectURL is where ZITADEL's end_session_endpoint sends the
+	// browser back to once a front-channel /auth/logout completes.
+	PostLogoutRedirectURL string `yaml:"post_logout_redirect_url"`
+
+	// The fields below configure -mode=m2m. Exactly one should be set,
+	// matching the kind of service account credential you created in
+	// ZITADEL.
+	ServiceAccountKeyPath string   `yaml:"service_account_key_path"`
+	ClientSecret          string   `yaml:"client_secret"`
+	PAT                   string   `yaml:"personal_access_token"`
+	Scopes                []string `yaml:"scopes"`
 }
 
 func LoadConfig(s string) (Config, error) {
@@ -160,6 +440,150 @@ func LoadConfig(s string) (Config, error) {
 	return c, nil
 }
 
+// newLogger builds the slog.Logger passed to authentication.WithLogger and
+// reqlog.Middleware, selected via the -log-format flag.
+func newLogger(format string) *slog.Logger {
+	switch format {
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	default:
+		return slog.New(slog.NewTextHandler(os.Stdout, nil))
+	}
+}
+
+// newSessionStore builds the sessionstore.Store backend selected via the
+// -session-store flag. "memory" needs no dsn; "redis" and "postgres" expect
+// dsn to be a connection string for the respective driver.
+func newSessionStore(kind, dsn string) (sessionstore.Store, error) {
+	switch kind {
+	case "memory", "":
+		return sessionstore.NewMemory(), nil
+	case "redis":
+		opts, err := redis.ParseURL(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("parsing redis session store dsn: %w", err)
+		}
+		return sessionstore.NewRedis(redis.NewClient(opts), "zitadel-example"), nil
+	case "postgres":
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return nil, fmt.Errorf("opening postgres session store: %w", err)
+		}
+		return sessionstore.NewPostgres(db), nil
+	default:
+		return nil, fmt.Errorf("unknown session store %q", kind)
+	}
+}
+
+// newResourceServer builds the rs.ResourceServer used to introspect bearer
+// tokens presented to /api/me. It authenticates to the introspection
+// endpoint with whichever -mode=m2m credential is configured, since a
+// resource server needs its own client credential for the same reason a
+// service account does; if none is configured, introspection has no way to
+// authenticate itself and /api/me cannot be served.
+func newResourceServer(ctx context.Context, config Config) (rs.ResourceServer, error) {
+	switch {
+	case config.ServiceAccountKeyPath != "":
+		return rs.NewResourceServerFromKeyFile(ctx, config.Issuer, config.ServiceAccountKeyPath)
+	case config.ClientSecret != "":
+		return rs.NewResourceServerClientCredentials(ctx, config.Issuer, config.ClientID, config.ClientSecret)
+	default:
+		return nil, errors.New("/api/me requires service_account_key_path or client_secret in the config for token introspection")
+	}
+}
+
+// runM2M demonstrates authenticating the process itself (rather than an end
+// user) against ZITADEL's API, using whichever credential kind is
+// configured. The resulting *zitadelclient.Client also carries the
+// credential as gRPC per-RPC credentials, so it can be used for gRPC calls
+// (e.g. api.ManagementService()...) as well as the plain HTTP call below.
+func runM2M(ctx context.Context, config Config) error {
+	auth, err := newM2MAuth(config)
+	if err != nil {
+		return fmt.Errorf("building service account auth: %w", err)
+	}
+
+	api, err := zitadelclient.New(ctx, zitadel.New(config.ZitadelDomain, zitadel.WithInsecure(config.ZitadelPort)), zitadelclient.WithAuth(auth))
+	if err != nil {
+		return fmt.Errorf("initializing zitadel client: %w", err)
+	}
+
+	token, err := api.GetValidToken()
+	if err != nil {
+		return fmt.Errorf("retrieving access token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, config.Issuer+"/oidc/v1/userinfo", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling userinfo endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var userInfo map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
+		return fmt.Errorf("decoding userinfo response: %w", err)
+	}
+	slog.Info("authenticated as service account", "userinfo", userInfo)
+	return nil
+}
+
+// newM2MAuth picks the zitadelclient.TokenSourceInitializer matching
+// whichever service account credential is configured.
+func newM2MAuth(config Config) (zitadelclient.TokenSourceInitializer, error) {
+	switch {
+	case config.ServiceAccountKeyPath != "":
+		return zitadelclient.DefaultServiceUserAuthentication(config.ServiceAccountKeyPath, config.Scopes...), nil
+	case config.ClientSecret != "":
+		return zitadelclient.PasswordAuthentication(config.ClientID, config.ClientSecret, config.Scopes...), nil
+	case config.PAT != "":
+		return zitadelclient.PAT(config.PAT), nil
+	default:
+		return nil, errors.New("m2m mode requires service_account_key_path, client_secret or personal_access_token in the config")
+	}
+}
+
+// statusRecorder captures the status code a wrapped handler wrote, without
+// otherwise altering what's sent to the client.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// sessionIDOf returns the "sid" claim from authCtx's ID token, or "" if
+// authCtx carries no tokens.
+func sessionIDOf(authCtx *openid.DefaultContext) string {
+	tokens := authCtx.GetTokens()
+	if tokens == nil {
+		return ""
+	}
+	return tokens.IDTokenClaims.SessionID
+}
+
+// handlerSet adapts a fixed set of already-built http.Handlers to the
+// adapters.Handlers interface, so the chi/gorillamux adapter packages can
+// register them without knowing anything about ZITADEL or our own
+// backchannel-logout handler.
+type handlerSet struct {
+	login, callback, logout, backchannelLogout http.Handler
+}
+
+func (h handlerSet) LoginHandler() http.Handler             { return h.login }
+func (h handlerSet) CallbackHandler() http.Handler          { return h.callback }
+func (h handlerSet) LogoutHandler() http.Handler            { return h.logout }
+func (h handlerSet) BackchannelLogoutHandler() http.Handler { return h.backchannelLogout }
+
+var _ adapters.Handlers = handlerSet{}
+
 func GenerateSecureToken(length int) string {
 	b := make([]byte, length)
 	if _, err := rand.Read(b); err != nil {
@@ -0,0 +1,44 @@
+// Package reqlog provides request-scoped structured logging: a correlation
+// id generated per request, an access log line emitted once the handler
+// returns, and a logger carrying that id available to handlers so their own
+// log lines can be traced back to the same request.
+package reqlog
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type contextKey struct{}
+
+// FromContext returns the request-scoped logger [Middleware] stored in ctx,
+// or slog.Default() if ctx wasn't produced by it.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// Middleware tags every request with a correlation id, logs an INFO access
+// line once the request completes, and makes a logger carrying that id
+// available to handlers via FromContext.
+func Middleware(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			scoped := logger.With("request_id", uuid.NewString())
+			start := time.Now()
+			next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), contextKey{}, scoped)))
+			scoped.Info("request handled",
+				"method", req.Method,
+				"path", req.URL.Path,
+				"remote_addr", req.RemoteAddr,
+				"duration", time.Since(start),
+			)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package sessionstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	openid "github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc"
+)
+
+// Postgres is a Store backed by a Postgres table, suitable for deployments
+// that already operate Postgres and want revocation state to survive a
+// restart without standing up Redis. The table is expected to have been
+// created with the schema in schema.sql.
+type Postgres struct {
+	db *sql.DB
+}
+
+// NewPostgres returns a Store backed by the given, already-opened database
+// handle.
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+// Get implements [authentication.Sessions].
+func (p *Postgres) Get(id string) (*openid.DefaultContext, error) {
+	row := p.db.QueryRowContext(context.Background(), `
+		SELECT session_data FROM sessions WHERE id = $1 AND expires_at > now()`, id)
+	var data []byte
+	err := row.Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var sess openid.DefaultContext
+	if err := json.Unmarshal(data, &sess); err != nil {
+		return nil, err
+	}
+	return &sess, nil
+}
+
+// Set implements [authentication.Sessions].
+func (p *Postgres) Set(id string, session *openid.DefaultContext) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	_, err = p.db.ExecContext(context.Background(), `
+		INSERT INTO sessions (id, user_id, session_data, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET session_data = EXCLUDED.session_data, expires_at = EXCLUDED.expires_at`,
+		id, session.UserInfo.GetSubject(), data, expiryOf(session))
+	return err
+}
+
+func (p *Postgres) Delete(ctx context.Context, id string) error {
+	_, err := p.db.ExecContext(ctx, `DELETE FROM sessions WHERE id = $1`, id)
+	return err
+}
+
+func (p *Postgres) List(ctx context.Context, userID string) ([]string, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT id FROM sessions WHERE user_id = $1 AND expires_at > now()`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,106 @@
+package sessionstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	openid "github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc"
+)
+
+// Redis is a Store backed by a Redis instance, suitable for multi-instance
+// deployments that need to share revocation state across replicas. Sessions
+// are additionally expired by Redis itself via a TTL matching expiryOf, so a
+// session that's never explicitly deleted is still dropped on schedule.
+type Redis struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewRedis returns a Store backed by the given Redis client. keyPrefix is
+// prepended to every key written by this store, so a single Redis instance
+// can be shared with other applications.
+func NewRedis(client *redis.Client, keyPrefix string) *Redis {
+	return &Redis{client: client, keyPrefix: keyPrefix}
+}
+
+func (r *Redis) sessionKey(id string) string {
+	return fmt.Sprintf("%s:session:%s", r.keyPrefix, id)
+}
+
+func (r *Redis) userIndexKey(userID string) string {
+	return fmt.Sprintf("%s:user:%s:sessions", r.keyPrefix, userID)
+}
+
+// Get implements [authentication.Sessions].
+func (r *Redis) Get(id string) (*openid.DefaultContext, error) {
+	ctx := context.Background()
+	data, err := r.client.Get(ctx, r.sessionKey(id)).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	var sess openid.DefaultContext
+	if err := json.Unmarshal(data, &sess); err != nil {
+		return nil, err
+	}
+	return &sess, nil
+}
+
+// Set implements [authentication.Sessions].
+func (r *Redis) Set(id string, session *openid.DefaultContext) error {
+	ctx := context.Background()
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(expiryOf(session))
+	if ttl <= 0 {
+		// go-redis writes a key with no expiry for a non-positive TTL, so an
+		// already expired session is removed instead of stored.
+		return r.Delete(ctx, id)
+	}
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, r.sessionKey(id), data, ttl)
+	pipe.SAdd(ctx, r.userIndexKey(session.UserInfo.GetSubject()), id)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *Redis) Delete(ctx context.Context, id string) error {
+	sess, err := r.Get(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil
+		}
+		return err
+	}
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, r.sessionKey(id))
+	pipe.SRem(ctx, r.userIndexKey(sess.UserInfo.GetSubject()), id)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *Redis) List(ctx context.Context, userID string) ([]string, error) {
+	ids, err := r.client.SMembers(ctx, r.userIndexKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	live := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := r.Get(id); err != nil {
+			if err == ErrNotFound {
+				r.client.SRem(ctx, r.userIndexKey(userID), id)
+				continue
+			}
+			return nil, err
+		}
+		live = append(live, id)
+	}
+	return live, nil
+}
@@ -0,0 +1,132 @@
+// Package sessionstore provides pluggable server-side persistence for
+// authentication sessions, so that active sessions can be enumerated and
+// forcibly revoked by an operator instead of only expiring client-side.
+//
+// Store implementations double as an [authentication.Sessions] backend:
+// passing one to [authentication.WithSessionStore] makes the SDK itself call
+// Set on every successful login and Get on every authenticated request,
+// which is what actually populates the store. Get/Set therefore cannot take
+// a context.Context - their signature is fixed by [authentication.Sessions] -
+// so Delete and List, which are called only by the example's own handlers
+// (the /auth/sessions endpoint and back-channel logout), take one instead.
+package sessionstore
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/authentication"
+	openid "github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc"
+
+	"github.com/gitu/zitadel-example/go-app/authlog"
+)
+
+// ErrNotFound is returned by Store implementations when a session id does
+// not exist, or has already expired.
+var ErrNotFound = errors.New("sessionstore: session not found")
+
+// defaultTTL bounds the lifetime of a session whose tokens don't carry their
+// own expiry.
+const defaultTTL = 1 * time.Hour
+
+// expiryOf derives a session's expiry from its access token, falling back to
+// defaultTTL if the token (or its expiry) is absent.
+func expiryOf(session *openid.DefaultContext) time.Time {
+	if tokens := session.GetTokens(); tokens != nil && tokens.Token != nil && !tokens.Expiry.IsZero() {
+		return tokens.Expiry
+	}
+	return time.Now().Add(defaultTTL)
+}
+
+// Store persists sessions so they can be looked up by the SDK, listed per
+// user and revoked independently of the encrypted session cookie. A session
+// that has expired must be treated as absent by Get and must not be
+// returned by List.
+type Store interface {
+	// Get and Set implement [authentication.Sessions], so a Store can be
+	// passed directly to [authentication.WithSessionStore].
+	Get(id string) (*openid.DefaultContext, error)
+	Set(id string, session *openid.DefaultContext) error
+
+	// Delete revokes a session: once it returns, the session id its cookie
+	// carries no longer resolves via Get, so the next request using it is
+	// treated as unauthenticated.
+	Delete(ctx context.Context, id string) error
+	// List returns the ids of all non-expired sessions belonging to userID.
+	List(ctx context.Context, userID string) ([]string, error)
+}
+
+// Memory is an in-memory Store, suitable for local development and for
+// single-instance deployments. Sessions do not survive a process restart.
+type Memory struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	session   *openid.DefaultContext
+	expiresAt time.Time
+}
+
+// NewMemory returns an empty in-memory Store.
+func NewMemory() *Memory {
+	return &Memory{entries: make(map[string]memoryEntry)}
+}
+
+func (m *Memory) Get(id string) (*openid.DefaultContext, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(m.entries, id)
+		// [authentication.Sessions.Get] is called by the SDK with just an id
+		// and no context.Context or *http.Request, so this is the only
+		// place SessionExpired can be emitted from and carries less
+		// metadata than the request-driven authlog events.
+		authlog.SessionExpired(slog.Default(), id, e.session.UserInfo.GetSubject())
+		return nil, ErrNotFound
+	}
+	return e.session, nil
+}
+
+func (m *Memory) Set(id string, session *openid.DefaultContext) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[id] = memoryEntry{session: session, expiresAt: expiryOf(session)}
+	return nil
+}
+
+func (m *Memory) Delete(_ context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, id)
+	return nil
+}
+
+func (m *Memory) List(_ context.Context, userID string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	var ids []string
+	for id, e := range m.entries {
+		if now.After(e.expiresAt) {
+			delete(m.entries, id)
+			continue
+		}
+		if e.session.UserInfo.GetSubject() == userID {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+var (
+	_ Store                                           = (*Memory)(nil)
+	_ authentication.Sessions[*openid.DefaultContext] = (*Memory)(nil)
+)
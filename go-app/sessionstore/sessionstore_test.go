@@ -0,0 +1,119 @@
+package sessionstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	openid "github.com/zitadel/zitadel-go/v3/pkg/authentication/oidc"
+	"golang.org/x/oauth2"
+)
+
+func newSession(sub string, expiry time.Time) *openid.DefaultContext {
+	s := &openid.DefaultContext{
+		UserInfo: &oidc.UserInfo{Subject: sub},
+	}
+	s.SetTokens(&oidc.Tokens[*oidc.IDTokenClaims]{
+		Token:         &oauth2.Token{Expiry: expiry},
+		IDTokenClaims: &oidc.IDTokenClaims{},
+	})
+	return s
+}
+
+func TestMemoryGetSet(t *testing.T) {
+	m := NewMemory()
+	session := newSession("user-1", time.Now().Add(time.Hour))
+
+	if err := m.Set("sess-1", session); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, err := m.Get("sess-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.UserInfo.GetSubject() != "user-1" {
+		t.Errorf("subject = %q, want %q", got.UserInfo.GetSubject(), "user-1")
+	}
+}
+
+func TestMemoryGetNotFound(t *testing.T) {
+	m := NewMemory()
+	if _, err := m.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryGetExpired(t *testing.T) {
+	m := NewMemory()
+	session := newSession("user-1", time.Now().Add(-time.Minute))
+	if err := m.Set("sess-1", session); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if _, err := m.Get("sess-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(expired) error = %v, want ErrNotFound", err)
+	}
+	// Expired entries are pruned on read.
+	if _, err := m.Get("sess-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Get(expired) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryDelete(t *testing.T) {
+	m := NewMemory()
+	session := newSession("user-1", time.Now().Add(time.Hour))
+	if err := m.Set("sess-1", session); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := m.Delete(context.Background(), "sess-1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := m.Get("sess-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete error = %v, want ErrNotFound", err)
+	}
+	// Deleting a non-existent id is a no-op, not an error.
+	if err := m.Delete(context.Background(), "never-existed"); err != nil {
+		t.Errorf("Delete(never-existed) = %v, want nil", err)
+	}
+}
+
+func TestMemoryList(t *testing.T) {
+	m := NewMemory()
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Minute)
+
+	must := func(id string, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Set(%s): %v", id, err)
+		}
+	}
+	must("sess-1", m.Set("sess-1", newSession("user-1", future)))
+	must("sess-2", m.Set("sess-2", newSession("user-1", future)))
+	must("sess-3", m.Set("sess-3", newSession("user-2", future)))
+	must("sess-4", m.Set("sess-4", newSession("user-1", past)))
+
+	ids, err := m.List(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("List(user-1) = %v, want 2 ids", ids)
+	}
+	for _, id := range ids {
+		if id != "sess-1" && id != "sess-2" {
+			t.Errorf("unexpected id %q in List(user-1) result", id)
+		}
+	}
+
+	ids, err = m.List(context.Background(), "user-3")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("List(user-3) = %v, want none", ids)
+	}
+}